@@ -0,0 +1,238 @@
+package hashlife
+
+// Node is a quadtree cell covering a 2^Level x 2^Level square. Level 0
+// nodes are single cells (Alive set, children nil); every other level is
+// split into four 2^(Level-1) quadrants. Nodes are hash-consed: two nodes
+// with identical children (by pointer) are always the same *Node, which is
+// what lets Combine and Successor memoize on pointer identity alone.
+type Node struct {
+	Level          int
+	NW, NE, SW, SE *Node
+	Alive          bool
+	Population     uint64
+
+	// result caches Successor(n, n.Level-2, rule) — the full-depth step —
+	// since that's the only depth repeatedly asked for while simulating.
+	// It's only valid for the rule it was computed with.
+	result     *Node
+	resultRule Rule
+	hasResult  bool
+}
+
+var (
+	deadLeaf  = &Node{Level: 0, Alive: false}
+	aliveLeaf = &Node{Level: 0, Alive: true, Population: 1}
+)
+
+func leaf(alive bool) *Node {
+	if alive {
+		return aliveLeaf
+	}
+	return deadLeaf
+}
+
+type nodeKey struct {
+	nw, ne, sw, se *Node
+}
+
+// canon is global and never evicted, so nodes built by any Universe (and
+// any FromDense call, including every array<->hashlife toggle) accumulate
+// here for the life of the process. That's the standard hash-consing
+// tradeoff: it's what lets Combine/Successor memoize across separate
+// universes and separate Step calls via pointer identity alone, but it
+// means a long-running interactive session leaks memory for subtrees no
+// Universe references anymore. Acceptable for this project's scale; a
+// longer-lived server process would want this scoped per-Universe (or
+// periodically rebuilt) with a real eviction policy instead.
+var canon = map[nodeKey]*Node{}
+
+// Combine hash-conses four same-level quadrants into their parent node.
+func Combine(nw, ne, sw, se *Node) *Node {
+	if nw.Level != ne.Level || nw.Level != sw.Level || nw.Level != se.Level {
+		panic("hashlife: Combine requires quadrants of equal level")
+	}
+
+	key := nodeKey{nw, ne, sw, se}
+	if n, ok := canon[key]; ok {
+		return n
+	}
+
+	n := &Node{
+		Level:      nw.Level + 1,
+		NW:         nw,
+		NE:         ne,
+		SW:         sw,
+		SE:         se,
+		Population: nw.Population + ne.Population + sw.Population + se.Population,
+	}
+	canon[key] = n
+	return n
+}
+
+var emptyCache = []*Node{deadLeaf}
+
+// EmptyNode returns the canonical all-dead node of the given level.
+func EmptyNode(level int) *Node {
+	for len(emptyCache) <= level {
+		prev := emptyCache[len(emptyCache)-1]
+		emptyCache = append(emptyCache, Combine(prev, prev, prev, prev))
+	}
+	return emptyCache[level]
+}
+
+// GetCell reads the cell at (x, y), where (0, 0) sits on the boundary
+// between the node's four quadrants (negative x falls in NW/SW, negative y
+// in NW/NE).
+func (n *Node) GetCell(x, y int64) bool {
+	if n.Level == 0 {
+		return n.Alive
+	}
+
+	// childOffset re-centers (x, y) onto the child quadrant's own
+	// coordinate system, whose half-width is half the parent's.
+	childOffset := (int64(1) << uint(n.Level-1)) / 2
+	switch {
+	case x < 0 && y < 0:
+		return n.NW.GetCell(x+childOffset, y+childOffset)
+	case x >= 0 && y < 0:
+		return n.NE.GetCell(x-childOffset, y+childOffset)
+	case x < 0 && y >= 0:
+		return n.SW.GetCell(x+childOffset, y-childOffset)
+	default:
+		return n.SE.GetCell(x-childOffset, y-childOffset)
+	}
+}
+
+// SetCell returns a new node equal to n but with (x, y) set to the given
+// alive state. x and y must already be within the node's bounds.
+func (n *Node) SetCell(x, y int64, alive bool) *Node {
+	if n.Level == 0 {
+		return leaf(alive)
+	}
+
+	childOffset := (int64(1) << uint(n.Level-1)) / 2
+	nw, ne, sw, se := n.NW, n.NE, n.SW, n.SE
+	switch {
+	case x < 0 && y < 0:
+		nw = nw.SetCell(x+childOffset, y+childOffset, alive)
+	case x >= 0 && y < 0:
+		ne = ne.SetCell(x-childOffset, y+childOffset, alive)
+	case x < 0 && y >= 0:
+		sw = sw.SetCell(x+childOffset, y-childOffset, alive)
+	default:
+		se = se.SetCell(x-childOffset, y-childOffset, alive)
+	}
+
+	return Combine(nw, ne, sw, se)
+}
+
+// quadrants returns the 3x3 overlapping grid of level-(n.Level-1) nodes
+// tiled across n's 2^Level square, built from n's grandchildren. n00/n02
+// /n20/n22 are exactly n's own children; the other five straddle two or
+// four of them.
+func (n *Node) quadrants() (n00, n01, n02, n10, n11, n12, n20, n21, n22 *Node) {
+	a, b, c, d := n.NW, n.NE, n.SW, n.SE
+
+	n00, n02, n20, n22 = a, b, c, d
+	n01 = Combine(a.NE, b.NW, a.SE, b.SW)
+	n10 = Combine(a.SW, a.SE, c.NW, c.NE)
+	n11 = Combine(a.SE, b.SW, c.NE, d.NW)
+	n12 = Combine(b.SW, b.SE, d.NW, d.NE)
+	n21 = Combine(c.NE, d.NW, c.SE, d.SW)
+	return
+}
+
+// Successor computes n stepped forward by 2^depth generations under rule,
+// returning a node one level down from n (centered on the same point).
+// depth must be between 0 and n.Level-2 inclusive; the full-depth case
+// (depth == n.Level-2) is the one memoized on n.
+func Successor(n *Node, depth int, rule Rule) *Node {
+	if n.Population == 0 {
+		return n.NW
+	}
+
+	if n.Level == 2 {
+		return n.level2Successor(rule)
+	}
+
+	maxDepth := n.Level - 2
+	if depth == maxDepth && n.hasResult && n.resultRule == rule {
+		return n.result
+	}
+
+	n00, n01, n02, n10, n11, n12, n20, n21, n22 := n.quadrants()
+
+	var nw, ne, sw, se *Node
+	if depth < maxDepth {
+		// A partial step only needs a single round of advancing each of
+		// the 9 overlapping quadrants by depth (rather than the
+		// max-depth case's two rounds). That leaves each r at the same
+		// level as the four outputs but offset from them by half its
+		// width, so the four outputs are re-assembled from the inner
+		// sub-children of four adjacent r's — the same overlap trick
+		// quadrants() uses one level up.
+		r00, r01, r02 := Successor(n00, depth, rule), Successor(n01, depth, rule), Successor(n02, depth, rule)
+		r10, r11, r12 := Successor(n10, depth, rule), Successor(n11, depth, rule), Successor(n12, depth, rule)
+		r20, r21, r22 := Successor(n20, depth, rule), Successor(n21, depth, rule), Successor(n22, depth, rule)
+
+		nw = Combine(r00.SE, r01.SW, r10.NE, r11.NW)
+		ne = Combine(r01.SE, r02.SW, r11.NE, r12.NW)
+		sw = Combine(r10.SE, r11.SW, r20.NE, r21.NW)
+		se = Combine(r11.SE, r12.SW, r21.NE, r22.NW)
+	} else {
+		r00, r01, r02 := Successor(n00, n00.Level-2, rule), Successor(n01, n01.Level-2, rule), Successor(n02, n02.Level-2, rule)
+		r10, r11, r12 := Successor(n10, n10.Level-2, rule), Successor(n11, n11.Level-2, rule), Successor(n12, n12.Level-2, rule)
+		r20, r21, r22 := Successor(n20, n20.Level-2, rule), Successor(n21, n21.Level-2, rule), Successor(n22, n22.Level-2, rule)
+
+		nwQuad := Combine(r00, r01, r10, r11)
+		neQuad := Combine(r01, r02, r11, r12)
+		swQuad := Combine(r10, r11, r20, r21)
+		seQuad := Combine(r11, r12, r21, r22)
+
+		nw = Successor(nwQuad, nwQuad.Level-2, rule)
+		ne = Successor(neQuad, neQuad.Level-2, rule)
+		sw = Successor(swQuad, swQuad.Level-2, rule)
+		se = Successor(seQuad, seQuad.Level-2, rule)
+	}
+
+	result := Combine(nw, ne, sw, se)
+
+	if depth == maxDepth {
+		n.result = result
+		n.resultRule = rule
+		n.hasResult = true
+	}
+
+	return result
+}
+
+// level2Successor computes the inner 2x2 next generation of a 4x4 (level
+// 2) node directly from the rule, with no further quadtree recursion.
+func (n *Node) level2Successor(rule Rule) *Node {
+	var grid [4][4]bool
+	grid[0][0], grid[1][0], grid[0][1], grid[1][1] = n.NW.NW.Alive, n.NW.NE.Alive, n.NW.SW.Alive, n.NW.SE.Alive
+	grid[2][0], grid[3][0], grid[2][1], grid[3][1] = n.NE.NW.Alive, n.NE.NE.Alive, n.NE.SW.Alive, n.NE.SE.Alive
+	grid[0][2], grid[1][2], grid[0][3], grid[1][3] = n.SW.NW.Alive, n.SW.NE.Alive, n.SW.SW.Alive, n.SW.SE.Alive
+	grid[2][2], grid[3][2], grid[2][3], grid[3][3] = n.SE.NW.Alive, n.SE.NE.Alive, n.SE.SW.Alive, n.SE.SE.Alive
+
+	next := func(cx, cy int) bool {
+		count := 0
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if dx == 0 && dy == 0 {
+					continue
+				}
+				if grid[cx+dx][cy+dy] {
+					count++
+				}
+			}
+		}
+		mask := uint16(1) << uint(count)
+		if grid[cx][cy] {
+			return rule.Survival&mask != 0
+		}
+		return rule.Birth&mask != 0
+	}
+
+	return Combine(leaf(next(1, 1)), leaf(next(2, 1)), leaf(next(1, 2)), leaf(next(2, 2)))
+}
@@ -0,0 +1,13 @@
+package hashlife
+
+// Rule is a Life-like rule compiled into two bitmasks indexed by live
+// neighbor count (0-8). Bit n is set when that count triggers a
+// birth/survival. It mirrors the Rule type in the main package so the two
+// engines agree on semantics without the hashlife package importing main.
+type Rule struct {
+	Birth    uint16
+	Survival uint16
+}
+
+// ConwayRule is the classic B3/S23 rule.
+var ConwayRule = Rule{Birth: 1 << 3, Survival: 1<<2 | 1<<3}
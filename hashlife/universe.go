@@ -0,0 +1,140 @@
+package hashlife
+
+// Universe is a Hashlife simulation: a single quadtree root plus the rule
+// it evolves under. (0, 0) is the center of the root; the root is grown
+// as cells are set or as Step needs more headroom, and each power-of-two
+// jump inside Step leaves it one level smaller (Successor's result).
+type Universe struct {
+	root *Node
+	rule Rule
+}
+
+// NewUniverse returns an empty universe simulated under rule.
+func NewUniverse(rule Rule) *Universe {
+	return &Universe{root: EmptyNode(3), rule: rule}
+}
+
+// Rule returns the rule the universe evolves under.
+func (u *Universe) Rule() Rule {
+	return u.rule
+}
+
+// Bounds returns the half-width of the root node: valid coordinates for
+// GetCell/SetCell currently span [-half, half) on both axes, though
+// SetCell will grow the universe to fit coordinates outside that range.
+func (u *Universe) Bounds() int64 {
+	return int64(1) << uint(u.root.Level-1)
+}
+
+// GetCell reports whether the cell at (x, y) is alive.
+func (u *Universe) GetCell(x, y int64) bool {
+	if !u.inBounds(x, y) {
+		return false
+	}
+	return u.root.GetCell(x, y)
+}
+
+// SetCell sets the cell at (x, y), growing the universe first if needed.
+func (u *Universe) SetCell(x, y int64, alive bool) {
+	for !u.inBounds(x, y) {
+		u.expand()
+	}
+	u.root = u.root.SetCell(x, y, alive)
+}
+
+func (u *Universe) inBounds(x, y int64) bool {
+	half := u.Bounds()
+	return x >= -half && x < half && y >= -half && y < half
+}
+
+// expand raises the root by one level (doubling its width), padding the
+// new border with empty space and keeping existing content centered.
+func (u *Universe) expand() {
+	empty := EmptyNode(u.root.Level - 1)
+	nw := Combine(empty, empty, empty, u.root.NW)
+	ne := Combine(empty, empty, u.root.NE, empty)
+	sw := Combine(empty, u.root.SW, empty, empty)
+	se := Combine(u.root.SE, empty, empty, empty)
+	u.root = Combine(nw, ne, sw, se)
+}
+
+// confinedToInnerQuarter reports whether every live cell in the root lies
+// within its inner quarter: the center half of the root's own center half.
+// Successor only ever returns that center half, one level down, so a cell
+// that ends up outside the center half during the step would fall outside
+// the new root and be lost; staying confined to the inner quarter leaves
+// a margin of 2^(Level-3) cells on every side to absorb that growth.
+func (u *Universe) confinedToInnerQuarter() bool {
+	_, _, _, _, half, _, _, _, _ := u.root.quadrants()
+	_, _, _, _, quarter, _, _, _, _ := half.quadrants()
+	return quarter.Population == u.root.Population
+}
+
+// Step advances the universe by n generations. Internally it decomposes n
+// into powers of two and applies each one via Successor, which is where
+// the algorithm's memoized speedup comes from.
+func (u *Universe) Step(n uint64) {
+	for n > 0 {
+		depth := trailingZeros(n)
+
+		// Successor itself needs depth <= root.Level-2; this keeps
+		// depth <= root.Level-4, plus confinement, so the 2^(Level-3)
+		// margin is at least twice the 2^depth cells the step can grow
+		// by and that growth can't spill past the edge of the half it
+		// keeps as the new root.
+		for !u.confinedToInnerQuarter() || u.root.Level-2 < depth+2 {
+			u.expand()
+		}
+
+		u.root = Successor(u.root, depth, u.rule)
+
+		n &^= 1 << uint(depth)
+	}
+}
+
+func trailingZeros(n uint64) int {
+	z := 0
+	for n&1 == 0 {
+		n >>= 1
+		z++
+	}
+	return z
+}
+
+// FromDense builds a universe from a dense grid indexed cells[x][y], with
+// cells[0][0] placed at (-width/2, -height/2) so the pattern ends up
+// centered on the origin.
+func FromDense(cells [][]bool, rule Rule) *Universe {
+	u := NewUniverse(rule)
+
+	width := len(cells)
+	if width == 0 {
+		return u
+	}
+	height := len(cells[0])
+
+	ox, oy := int64(-width/2), int64(-height/2)
+	for x, col := range cells {
+		for y, alive := range col {
+			if alive {
+				u.SetCell(ox+int64(x), oy+int64(y), true)
+			}
+		}
+	}
+
+	return u
+}
+
+// ToDense reads a width x height window of the universe back out as a
+// dense cells[x][y] grid, using the same centering as FromDense.
+func (u *Universe) ToDense(width, height int) [][]bool {
+	cells := make([][]bool, width)
+	ox, oy := int64(-width/2), int64(-height/2)
+	for x := range cells {
+		cells[x] = make([]bool, height)
+		for y := range cells[x] {
+			cells[x][y] = u.GetCell(ox+int64(x), oy+int64(y))
+		}
+	}
+	return cells
+}
@@ -0,0 +1,142 @@
+package hashlife
+
+import "testing"
+
+// bruteStep advances a dense cells[x][y] grid by one generation under
+// rule, treating everything outside the grid as permanently dead — a
+// reference implementation independent of the quadtree to check
+// Universe.Step against.
+func bruteStep(cells [][]bool, rule Rule) [][]bool {
+	width := len(cells)
+	if width == 0 {
+		return cells
+	}
+	height := len(cells[0])
+
+	alive := func(x, y int) bool {
+		if x < 0 || x >= width || y < 0 || y >= height {
+			return false
+		}
+		return cells[x][y]
+	}
+
+	next := make([][]bool, width)
+	for x := range next {
+		next[x] = make([]bool, height)
+		for y := range next[x] {
+			count := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if dx == 0 && dy == 0 {
+						continue
+					}
+					if alive(x+dx, y+dy) {
+						count++
+					}
+				}
+			}
+			mask := uint16(1) << uint(count)
+			if cells[x][y] {
+				next[x][y] = rule.Survival&mask != 0
+			} else {
+				next[x][y] = rule.Birth&mask != 0
+			}
+		}
+	}
+	return next
+}
+
+// assertMatchesBrute steps a Universe seeded from cells by the given number
+// of generations (via one Step(1) call per generation, exercising
+// Successor's depth-0 path) and compares it, cell by cell, against
+// bruteStep after every generation.
+func assertMatchesBrute(t *testing.T, name string, cells [][]bool, generations int) {
+	t.Helper()
+
+	width, height := len(cells), len(cells[0])
+	u := FromDense(cells, ConwayRule)
+
+	want := cells
+	for gen := 1; gen <= generations; gen++ {
+		want = bruteStep(want, ConwayRule)
+		u.Step(1)
+
+		got := u.ToDense(width, height)
+		for x := 0; x < width; x++ {
+			for y := 0; y < height; y++ {
+				if got[x][y] != want[x][y] {
+					t.Fatalf("%s: generation %d mismatch at (%d, %d): got %v, want %v", name, gen, x, y, got[x][y], want[x][y])
+				}
+			}
+		}
+	}
+}
+
+func TestBlinker(t *testing.T) {
+	// A vertical blinker in a 7x7 field, centered so it stays well away
+	// from the window edge as it oscillates.
+	cells := make([][]bool, 7)
+	for x := range cells {
+		cells[x] = make([]bool, 7)
+	}
+	cells[2][3], cells[3][3], cells[4][3] = true, true, true
+
+	assertMatchesBrute(t, "blinker", cells, 6)
+}
+
+func TestGlider(t *testing.T) {
+	cells := make([][]bool, 12)
+	for x := range cells {
+		cells[x] = make([]bool, 12)
+	}
+	cells[1][0], cells[2][1], cells[0][2], cells[1][2], cells[2][2] = true, true, true, true, true
+
+	assertMatchesBrute(t, "glider", cells, 16)
+}
+
+// TestStepMultiGeneration exercises Successor's partial-depth branch
+// (Step decomposes n into several power-of-two jumps) by advancing a
+// glider many generations in one call and checking the end state
+// against repeated single-generation brute-force steps.
+func TestStepMultiGeneration(t *testing.T) {
+	width, height := 16, 16
+	cells := make([][]bool, width)
+	for x := range cells {
+		cells[x] = make([]bool, height)
+	}
+	cells[1][0], cells[2][1], cells[0][2], cells[1][2], cells[2][2] = true, true, true, true, true
+
+	want := cells
+	for i := 0; i < 11; i++ {
+		want = bruteStep(want, ConwayRule)
+	}
+
+	u := FromDense(cells, ConwayRule)
+	u.Step(11)
+	got := u.ToDense(width, height)
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			if got[x][y] != want[x][y] {
+				t.Fatalf("Step(11): mismatch at (%d, %d): got %v, want %v", x, y, got[x][y], want[x][y])
+			}
+		}
+	}
+}
+
+// TestRPentomino runs the R-pentomino, a five-cell pattern whose population
+// spreads outward almost every generation, for long enough to repeatedly
+// force the root to grow mid-simulation. It guards against Step losing
+// cells that are born right at the edge of its confinement margin.
+func TestRPentomino(t *testing.T) {
+	width, height := 40, 40
+	cells := make([][]bool, width)
+	for x := range cells {
+		cells[x] = make([]bool, height)
+	}
+	cells[width/2][height/2-1], cells[width/2+1][height/2-1] = true, true
+	cells[width/2-1][height/2], cells[width/2][height/2] = true, true
+	cells[width/2][height/2+1] = true
+
+	assertMatchesBrute(t, "r-pentomino", cells, 30)
+}
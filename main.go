@@ -1,26 +1,50 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"image/color"
 	"log"
+	"math"
 	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/lucaslopesx/game-of-life-go/hashlife"
+	"github.com/lucaslopesx/game-of-life-go/patterns"
 )
 
+// These are only the starting point now: ScreenWidth/Height seed the
+// initial (resizable) window, Cols/Rows/CellSize seed the Game fields
+// that Layout, zoom and Ctrl+=/Ctrl+- adjust at runtime.
 const (
-	ScreenWidth  = 800
-	ScreenHeight = 600
+	DefaultScreenWidth  = 800
+	DefaultScreenHeight = 600
 
-	Rows     = 40
-	Cols     = 60
-	CellSize = 12
+	DefaultRows     = 40
+	DefaultCols     = 60
+	DefaultCellSize = 12
 
 	UpdateInterval = 100
 	Ratio          = 0.3
+
+	MinZoom  = 0.1
+	MaxZoom  = 8.0
+	ZoomStep = 1.1
+
+	ResizeStep = 10
+
+	// patternPickerLineHeight and patternPickerCharWidth match
+	// ebitenutil.DebugPrintAt's fixed bitmap-font metrics, so pixel
+	// positions can be mapped back to rows and columns.
+	patternPickerLineHeight = 16
+	patternPickerCharWidth  = 6
 )
 
 const (
@@ -28,32 +52,141 @@ const (
 	Running
 )
 
+// EngineKind selects which simulation engine advances the grid.
+// EngineArray is the straightforward per-step scan; EngineHashlife uses
+// the memoized quadtree evaluator, which is far faster for large/sparse
+// patterns. It keeps the rule that was active when it was switched on and
+// ignores Grid.Wrap; cells can still be edited while it is active.
+type EngineKind int
+
+const (
+	EngineArray EngineKind = iota
+	EngineHashlife
+)
+
 var (
 	ColorBackground = color.RGBA{0, 0, 0, 255}
 	ColorGridLine   = color.RGBA{40, 40, 40, 255}
 	ColorLiveCell   = color.RGBA{240, 240, 240, 255}
 )
 
+// RulePresets are offered to cycle through with the R key. Conway's
+// original B3/S23 stays first so it remains the default.
+var RulePresets = []string{
+	"B3/S23",
+	"B36/S23",
+	"B2/S",
+	"B1/S12",
+}
+
 type GameState int
 
 type Game struct {
-	grid             *Grid
-	gridOffsetX      int
-	gridOffsetY      int
+	grid         *Grid
+	screenWidth  int
+	screenHeight int
+	cellSize     float64
+	zoom         float64
+	gridOffsetX  float64
+	gridOffsetY  float64
+	panning      bool
+	lastPanX     int
+	lastPanY     int
+
 	touchIds         []ebiten.TouchID
+	touchLastCell    map[ebiten.TouchID][2]int
+	pinching         bool
+	pinchStartDist   float64
+	pinchStartZoom   float64
+	pinchMidX        float64
+	pinchMidY        float64
 	lastTouchedCellX int
 	lastTouchedCellY int
 	state            GameState
 	lastUpdateTime   time.Time
 	rng              *rand.Rand
+	rulePresetIndex  int
+
+	patternPickerOpen bool
+	selectedPattern   int
+	armedPattern      *patterns.Pattern
+
+	engine   EngineKind
+	universe *hashlife.Universe
 }
 
 type Cell struct {
 	Alive bool
 }
 
+// Rule is a Life-like rule compiled from Golly B/S notation into two
+// bitmasks indexed by live-neighbor count (0-8). Bit n is set when that
+// neighbor count triggers a birth/survival.
+type Rule struct {
+	Birth    uint16
+	Survival uint16
+}
+
+// ParseRule parses a Golly-style B/S rulestring such as "B3/S23" (Conway),
+// "B36/S23" (HighLife) or "B2/S" (Seeds) into a Rule.
+func ParseRule(s string) (Rule, error) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("invalid rulestring %q: expected B.../S...", s)
+	}
+
+	bPart, sPart := parts[0], parts[1]
+	if !strings.HasPrefix(bPart, "B") || !strings.HasPrefix(sPart, "S") {
+		return Rule{}, fmt.Errorf("invalid rulestring %q: expected B.../S...", s)
+	}
+
+	birth, err := parseDigitMask(strings.TrimPrefix(bPart, "B"))
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rulestring %q: %w", s, err)
+	}
+
+	survival, err := parseDigitMask(strings.TrimPrefix(sPart, "S"))
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rulestring %q: %w", s, err)
+	}
+
+	return Rule{Birth: birth, Survival: survival}, nil
+}
+
+func parseDigitMask(digits string) (uint16, error) {
+	var mask uint16
+	for _, r := range digits {
+		n, err := strconv.Atoi(string(r))
+		if err != nil || n < 0 || n > 8 {
+			return 0, fmt.Errorf("invalid neighbor count %q", string(r))
+		}
+		mask |= 1 << uint(n)
+	}
+	return mask, nil
+}
+
+func (r Rule) String() string {
+	var b, s strings.Builder
+	for n := 0; n <= 8; n++ {
+		if r.Birth&(1<<uint(n)) != 0 {
+			fmt.Fprintf(&b, "%d", n)
+		}
+		if r.Survival&(1<<uint(n)) != 0 {
+			fmt.Fprintf(&s, "%d", n)
+		}
+	}
+	return fmt.Sprintf("B%s/S%s", b.String(), s.String())
+}
+
+// ConwayRule is the classic B3/S23 rule and remains the default.
+var ConwayRule = Rule{Birth: 1 << 3, Survival: 1<<2 | 1<<3}
+
 type Grid struct {
 	Cells [][]Cell
+	Cols  int
+	Rows  int
+	Rule  Rule
+	Wrap  bool
 }
 
 var directions = []struct{ dx, dy int }{
@@ -72,17 +205,43 @@ func (g *Grid) Randomize(rng *rand.Rand, ratio float64) {
 	}
 }
 
-func NewGrid() *Grid {
-	cells := make([][]Cell, Cols)
+func NewGrid(cols, rows int) *Grid {
+	cells := make([][]Cell, cols)
 	for x := range cells {
-		cells[x] = make([]Cell, Rows)
+		cells[x] = make([]Cell, rows)
 	}
 
 	return &Grid{
 		Cells: cells,
+		Cols:  cols,
+		Rows:  rows,
+		Rule:  ConwayRule,
 	}
 }
 
+// Resize grows or shrinks the grid to cols x rows, preserving whatever
+// cells still fall within the new bounds.
+func (grid *Grid) Resize(cols, rows int) {
+	if cols < 1 {
+		cols = 1
+	}
+	if rows < 1 {
+		rows = 1
+	}
+
+	cells := make([][]Cell, cols)
+	for x := range cells {
+		cells[x] = make([]Cell, rows)
+		if x < len(grid.Cells) {
+			copy(cells[x], grid.Cells[x])
+		}
+	}
+
+	grid.Cells = cells
+	grid.Cols = cols
+	grid.Rows = rows
+}
+
 func (g *Game) handleKeyboardInput() {
 	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
 		if g.state == Running {
@@ -91,23 +250,90 @@ func (g *Game) handleKeyboardInput() {
 			g.state = Running
 		}
 	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.rulePresetIndex = (g.rulePresetIndex + 1) % len(RulePresets)
+		rule, err := ParseRule(RulePresets[g.rulePresetIndex])
+		if err != nil {
+			log.Printf("skipping invalid rule preset %q: %v", RulePresets[g.rulePresetIndex], err)
+			return
+		}
+		g.grid.Rule = rule
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		g.grid.Wrap = !g.grid.Wrap
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyH) {
+		g.toggleEngine()
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyControl) {
+		if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+			g.grid.Resize(g.grid.Cols+ResizeStep, g.grid.Rows+ResizeStep)
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+			g.grid.Resize(g.grid.Cols-ResizeStep, g.grid.Rows-ResizeStep)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		g.patternPickerOpen = !g.patternPickerOpen
+		return
+	}
+
+	if !g.patternPickerOpen {
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		g.selectedPattern = (g.selectedPattern + 1) % len(patterns.Bundled)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		g.selectedPattern = (g.selectedPattern - 1 + len(patterns.Bundled)) % len(patterns.Bundled)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		name := patterns.Bundled[g.selectedPattern]
+		pattern, err := patterns.LoadBundled(name)
+		if err != nil {
+			log.Printf("failed to arm pattern %q: %v", name, err)
+			return
+		}
+		g.armedPattern = pattern
+		g.patternPickerOpen = false
+	}
 }
 
 func (g *Game) handleMouseInput() {
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		mx, my := ebiten.CursorPosition()
+	g.handleZoom()
+	g.handlePan()
 
-		gridX := (mx - g.gridOffsetX) / CellSize
-		gridY := (my - g.gridOffsetY) / CellSize
+	if g.patternPickerOpen {
+		g.handlePatternPickerClick()
+		return
+	}
+
+	if g.armedPattern != nil {
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			gridX, gridY := g.cellAtCursor()
+			g.stampPattern(g.armedPattern, gridX, gridY, 0)
+			g.armedPattern = nil
+		}
+		return
+	}
 
-		if gridX < 0 || gridX >= Cols || gridY < 0 || gridY >= Rows {
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		gridX, gridY := g.cellAtCursor()
+
+		if gridX < 0 || gridX >= g.grid.Cols || gridY < 0 || gridY >= g.grid.Rows {
 			return
 		}
 
 		if gridX != g.lastTouchedCellX || gridY != g.lastTouchedCellY {
 			g.lastTouchedCellX = gridX
 			g.lastTouchedCellY = gridY
-			g.grid.Cells[gridX][gridY].Alive = !g.grid.Cells[gridX][gridY].Alive
+			g.toggleCell(gridX, gridY)
 		}
 	} else {
 		g.lastTouchedCellX = -1
@@ -115,11 +341,250 @@ func (g *Game) handleMouseInput() {
 	}
 }
 
+// gridToUniverse converts grid-space coordinates, as used by Grid.Cells and
+// Grid.Stamp, into the universe's centered coordinate system, matching the
+// centering FromDense/ToDense already use to keep the two engines in sync.
+func (g *Game) gridToUniverse(x, y int) (int64, int64) {
+	return int64(x) - int64(g.grid.Cols)/2, int64(y) - int64(g.grid.Rows)/2
+}
+
+// toggleCell flips the cell at grid-space (x, y) under whichever engine is
+// active. Editing used to only ever touch g.grid, so clicks and touches
+// while EngineHashlife was active silently wrote into cells drawCells never
+// renders, and got discarded the next time the user toggled back to
+// EngineArray. Writing through g.universe.SetCell instead keeps edits
+// visible (and preserved) under either engine.
+func (g *Game) toggleCell(x, y int) {
+	if g.engine == EngineHashlife {
+		ux, uy := g.gridToUniverse(x, y)
+		g.universe.SetCell(ux, uy, !g.universe.GetCell(ux, uy))
+		return
+	}
+	g.grid.Cells[x][y].Alive = !g.grid.Cells[x][y].Alive
+}
+
+// stampPattern writes pattern at grid-space (x, y) under whichever engine
+// is active, the same EngineHashlife-aware routing as toggleCell.
+func (g *Game) stampPattern(pattern *patterns.Pattern, x, y, rotation int) {
+	if g.engine == EngineHashlife {
+		for _, c := range rotatedPatternCells(pattern, x, y, rotation) {
+			ux, uy := g.gridToUniverse(c[0], c[1])
+			g.universe.SetCell(ux, uy, true)
+		}
+		return
+	}
+	g.grid.Stamp(pattern, x, y, rotation)
+}
+
+// effectiveCellSize is the on-screen pixel size of a cell after zoom.
+func (g *Game) effectiveCellSize() float64 {
+	return g.cellSize * g.zoom
+}
+
+// cellAt maps a screen position to grid coordinates under the current
+// pan/zoom transform.
+func (g *Game) cellAt(screenX, screenY int) (int, int) {
+	cellSize := g.effectiveCellSize()
+	gridX := int(math.Floor((float64(screenX) - g.gridOffsetX) / cellSize))
+	gridY := int(math.Floor((float64(screenY) - g.gridOffsetY) / cellSize))
+	return gridX, gridY
+}
+
+// cellAtCursor maps the mouse position to grid coordinates under the
+// current pan/zoom transform.
+func (g *Game) cellAtCursor() (int, int) {
+	mx, my := ebiten.CursorPosition()
+	return g.cellAt(mx, my)
+}
+
+// handleZoom applies mouse-wheel zoom, adjusting the pan offset so the
+// cell under the cursor stays fixed on screen.
+func (g *Game) handleZoom() {
+	_, wheelY := ebiten.Wheel()
+	if wheelY == 0 {
+		return
+	}
+
+	mx, my := ebiten.CursorPosition()
+	cellSize := g.effectiveCellSize()
+	worldX := (float64(mx) - g.gridOffsetX) / cellSize
+	worldY := (float64(my) - g.gridOffsetY) / cellSize
+
+	if wheelY > 0 {
+		g.zoom *= ZoomStep
+	} else {
+		g.zoom /= ZoomStep
+	}
+	g.zoom = math.Min(MaxZoom, math.Max(MinZoom, g.zoom))
+
+	newCellSize := g.effectiveCellSize()
+	g.gridOffsetX = float64(mx) - worldX*newCellSize
+	g.gridOffsetY = float64(my) - worldY*newCellSize
+}
+
+// handlePan drags the grid with the middle mouse button held.
+func (g *Game) handlePan() {
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle) {
+		g.panning = false
+		return
+	}
+
+	mx, my := ebiten.CursorPosition()
+	if g.panning {
+		g.gridOffsetX += float64(mx - g.lastPanX)
+		g.gridOffsetY += float64(my - g.lastPanY)
+	}
+	g.lastPanX, g.lastPanY = mx, my
+	g.panning = true
+}
+
+// handleTouchInput mirrors handleMouseInput for touchscreens: each active
+// touch toggles the cell under it (tracking a per-touch last-toggled cell
+// so a finger dragging across the grid doesn't re-toggle every frame), and
+// a second finger switches to pinch-to-zoom plus two-finger drag panning.
+func (g *Game) handleTouchInput() {
+	g.touchIds = ebiten.AppendTouchIDs(g.touchIds[:0])
+
+	if g.touchLastCell == nil {
+		g.touchLastCell = make(map[ebiten.TouchID][2]int)
+	}
+	active := make(map[ebiten.TouchID]bool, len(g.touchIds))
+	for _, id := range g.touchIds {
+		active[id] = true
+	}
+	for id := range g.touchLastCell {
+		if !active[id] {
+			delete(g.touchLastCell, id)
+		}
+	}
+
+	if len(g.touchIds) >= 2 {
+		g.handlePinchAndTwoFingerPan()
+		return
+	}
+	g.pinching = false
+
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		g.touchLastCell[id] = [2]int{-1, -1}
+	}
+
+	if g.armedPattern != nil {
+		for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+			tx, ty := ebiten.TouchPosition(id)
+			gridX, gridY := g.cellAt(tx, ty)
+			g.stampPattern(g.armedPattern, gridX, gridY, 0)
+			g.armedPattern = nil
+			break
+		}
+		return
+	}
+
+	for _, id := range g.touchIds {
+		tx, ty := ebiten.TouchPosition(id)
+		gridX, gridY := g.cellAt(tx, ty)
+
+		if gridX < 0 || gridX >= g.grid.Cols || gridY < 0 || gridY >= g.grid.Rows {
+			continue
+		}
+
+		last, ok := g.touchLastCell[id]
+		if !ok {
+			last = [2]int{-1, -1}
+		}
+		if last[0] == gridX && last[1] == gridY {
+			continue
+		}
+		g.touchLastCell[id] = [2]int{gridX, gridY}
+		g.toggleCell(gridX, gridY)
+	}
+}
+
+// handlePinchAndTwoFingerPan drives zoom from the distance between the
+// first two active touches and panning from the movement of their
+// midpoint, the touch equivalent of handleZoom/handlePan.
+func (g *Game) handlePinchAndTwoFingerPan() {
+	x0, y0 := ebiten.TouchPosition(g.touchIds[0])
+	x1, y1 := ebiten.TouchPosition(g.touchIds[1])
+
+	dist := math.Hypot(float64(x1-x0), float64(y1-y0))
+	midX, midY := float64(x0+x1)/2, float64(y0+y1)/2
+
+	if !g.pinching {
+		g.pinching = true
+		g.pinchStartDist = dist
+		g.pinchStartZoom = g.zoom
+		g.pinchMidX, g.pinchMidY = midX, midY
+		return
+	}
+
+	cellSize := g.effectiveCellSize()
+	worldX := (g.pinchMidX - g.gridOffsetX) / cellSize
+	worldY := (g.pinchMidY - g.gridOffsetY) / cellSize
+
+	if g.pinchStartDist > 0 {
+		newZoom := g.pinchStartZoom * (dist / g.pinchStartDist)
+		g.zoom = math.Min(MaxZoom, math.Max(MinZoom, newZoom))
+	}
+
+	newCellSize := g.effectiveCellSize()
+	g.gridOffsetX = g.pinchMidX - worldX*newCellSize
+	g.gridOffsetY = g.pinchMidY - worldY*newCellSize
+
+	g.gridOffsetX += midX - g.pinchMidX
+	g.gridOffsetY += midY - g.pinchMidY
+	g.pinchMidX, g.pinchMidY = midX, midY
+}
+
+// rotatedPatternCells returns pattern's live cells as absolute (x, y)
+// coordinates, rotated clockwise by rotation quarter turns (0-3) and placed
+// with its origin at (x, y). It's the shared geometry behind Grid.Stamp and
+// the EngineHashlife stamping path in stampPattern.
+func rotatedPatternCells(pattern *patterns.Pattern, x, y, rotation int) [][2]int {
+	rotation = ((rotation % 4) + 4) % 4
+
+	var cells [][2]int
+	for py, row := range pattern.Cells {
+		for px, alive := range row {
+			if !alive {
+				continue
+			}
+
+			var rx, ry int
+			switch rotation {
+			case 0:
+				rx, ry = px, py
+			case 1:
+				rx, ry = pattern.Height-1-py, px
+			case 2:
+				rx, ry = pattern.Width-1-px, pattern.Height-1-py
+			case 3:
+				rx, ry = py, pattern.Width-1-px
+			}
+
+			cells = append(cells, [2]int{x + rx, y + ry})
+		}
+	}
+	return cells
+}
+
+// Stamp writes pattern into the grid with its origin at (x, y), rotated
+// clockwise by rotation quarter turns (0-3). Cells that fall outside the
+// grid are silently dropped.
+func (grid *Grid) Stamp(pattern *patterns.Pattern, x, y, rotation int) {
+	for _, c := range rotatedPatternCells(pattern, x, y, rotation) {
+		gx, gy := c[0], c[1]
+		if gx < 0 || gx >= grid.Cols || gy < 0 || gy >= grid.Rows {
+			continue
+		}
+		grid.Cells[gx][gy].Alive = true
+	}
+}
+
 func (grid *Grid) CalculateNextGeneration() {
 	newCellsGeneration := make(map[int]map[int]bool)
-	for x := 0; x < Cols; x++ {
+	for x := 0; x < grid.Cols; x++ {
 		newCellsGeneration[x] = make(map[int]bool)
-		for y := 0; y < Rows; y++ {
+		for y := 0; y < grid.Rows; y++ {
 			newCellsGeneration[x][y] = false
 		}
 	}
@@ -127,22 +592,12 @@ func (grid *Grid) CalculateNextGeneration() {
 	for x, cells := range grid.Cells {
 		for y, cell := range cells {
 			liveNeighboors := grid.CountNeighboors(x, y)
-			if cell.Alive && liveNeighboors < 2 {
-				continue
-			}
-
-			if cell.Alive && (liveNeighboors == 2 || liveNeighboors == 3) {
-				newCellsGeneration[x][y] = true
-				continue
-			}
+			mask := uint16(1) << uint(liveNeighboors)
 
-			if cell.Alive && liveNeighboors > 3 {
-				continue
-			}
-
-			if !cell.Alive && liveNeighboors == 3 {
-				newCellsGeneration[x][y] = true
-				continue
+			if cell.Alive {
+				newCellsGeneration[x][y] = grid.Rule.Survival&mask != 0
+			} else {
+				newCellsGeneration[x][y] = grid.Rule.Birth&mask != 0
 			}
 		}
 	}
@@ -158,9 +613,14 @@ func (grid *Grid) CountNeighboors(x, y int) int {
 	count := 0
 	for _, v := range directions {
 		nx, ny := v.dx+x, v.dy+y
-		if nx < 0 || nx >= Cols || ny < 0 || ny >= Rows {
+
+		if grid.Wrap {
+			nx = (nx + grid.Cols) % grid.Cols
+			ny = (ny + grid.Rows) % grid.Rows
+		} else if nx < 0 || nx >= grid.Cols || ny < 0 || ny >= grid.Rows {
 			continue
 		}
+
 		if grid.Cells[nx][ny].Alive {
 			count++
 		}
@@ -170,19 +630,26 @@ func (grid *Grid) CountNeighboors(x, y int) int {
 }
 
 func (g *Game) drawCells(screen *ebiten.Image) {
-	for x, row := range g.grid.Cells {
-		for y, cell := range row {
+	dense := denseFromGrid(g.grid)
+	if g.engine == EngineHashlife {
+		dense = g.universe.ToDense(g.grid.Cols, g.grid.Rows)
+	}
 
-			posX := float32(g.gridOffsetX + x*CellSize)
-			posY := float32(g.gridOffsetY + y*CellSize)
+	cellSize := float32(g.effectiveCellSize())
+	offsetX, offsetY := float32(g.gridOffsetX), float32(g.gridOffsetY)
 
-			if cell.Alive {
+	for x, row := range dense {
+		for y, alive := range row {
+			posX := offsetX + float32(x)*cellSize
+			posY := offsetY + float32(y)*cellSize
+
+			if alive {
 				vector.DrawFilledRect(
 					screen,
 					posX,
 					posY,
-					CellSize,
-					CellSize,
+					cellSize,
+					cellSize,
 					ColorLiveCell,
 					false,
 				)
@@ -192,13 +659,16 @@ func (g *Game) drawCells(screen *ebiten.Image) {
 }
 
 func (g *Game) drawGrid(screen *ebiten.Image) {
-	for y := 0; y <= Rows; y++ {
-		lineY := float32(g.gridOffsetY + y*CellSize)
+	cellSize := float32(g.effectiveCellSize())
+	offsetX, offsetY := float32(g.gridOffsetX), float32(g.gridOffsetY)
+
+	for y := 0; y <= g.grid.Rows; y++ {
+		lineY := offsetY + float32(y)*cellSize
 		vector.StrokeLine(
 			screen,
-			float32(g.gridOffsetX),
+			offsetX,
 			lineY,
-			float32(g.gridOffsetX+Cols*CellSize),
+			offsetX+float32(g.grid.Cols)*cellSize,
 			lineY,
 			1.0,
 			ColorGridLine,
@@ -206,14 +676,14 @@ func (g *Game) drawGrid(screen *ebiten.Image) {
 		)
 	}
 
-	for x := 0; x <= Cols; x++ {
-		lineX := float32(g.gridOffsetX + x*CellSize)
+	for x := 0; x <= g.grid.Cols; x++ {
+		lineX := offsetX + float32(x)*cellSize
 		vector.StrokeLine(
 			screen,
 			lineX,
-			float32(g.gridOffsetY),
+			offsetY,
 			lineX,
-			float32(g.gridOffsetY+Rows*CellSize),
+			offsetY+float32(g.grid.Rows)*cellSize,
 			1.0,
 			ColorGridLine,
 			false,
@@ -226,10 +696,75 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 	g.drawCells(screen)
 	g.drawGrid(screen)
+	g.drawPatternPicker(screen)
+}
+
+func (g *Game) drawPatternPicker(screen *ebiten.Image) {
+	if !g.patternPickerOpen {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("Pick a pattern (click, or Up/Down, Enter):\n")
+	for i, name := range patterns.Bundled {
+		cursor := "  "
+		if i == g.selectedPattern {
+			cursor = "> "
+		}
+		b.WriteString(cursor + name + "\n")
+	}
+
+	ebitenutil.DebugPrintAt(screen, b.String(), int(g.gridOffsetX), int(g.gridOffsetY))
+}
+
+// patternPickerWidth is the pixel width of the widest line drawPatternPicker
+// renders (the "> name" cursor prefix included), so handlePatternPickerClick's
+// hitbox always matches the text on screen instead of a guessed constant.
+func (g *Game) patternPickerWidth() int {
+	widest := 0
+	for _, name := range patterns.Bundled {
+		if w := len("> " + name); w > widest {
+			widest = w
+		}
+	}
+	return widest * patternPickerCharWidth
+}
+
+// handlePatternPickerClick arms a pattern when the user clicks its row in
+// the overlay drawPatternPicker draws, letting a click do what Up/Down+
+// Enter already did. Row i sits one patternPickerLineHeight below the
+// header line, which itself starts at the overlay's top-left corner.
+func (g *Game) handlePatternPickerClick() {
+	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return
+	}
+
+	mx, my := ebiten.CursorPosition()
+	if float64(mx) < g.gridOffsetX || float64(mx) > g.gridOffsetX+float64(g.patternPickerWidth()) {
+		return
+	}
+
+	for i, name := range patterns.Bundled {
+		rowTop := int(g.gridOffsetY) + (i+1)*patternPickerLineHeight
+		if my < rowTop || my >= rowTop+patternPickerLineHeight {
+			continue
+		}
+
+		pattern, err := patterns.LoadBundled(name)
+		if err != nil {
+			log.Printf("failed to arm pattern %q: %v", name, err)
+			return
+		}
+		g.selectedPattern = i
+		g.armedPattern = pattern
+		g.patternPickerOpen = false
+		return
+	}
 }
 
 func (g *Game) Update() error {
 	g.handleMouseInput()
+	g.handleTouchInput()
 	g.handleKeyboardInput()
 
 	if g.state == Paused {
@@ -240,22 +775,69 @@ func (g *Game) Update() error {
 		return nil
 	}
 
-	g.grid.CalculateNextGeneration()
+	switch g.engine {
+	case EngineHashlife:
+		g.universe.Step(1)
+	default:
+		g.grid.CalculateNextGeneration()
+	}
 
 	g.lastUpdateTime = time.Now()
 
 	return nil
 }
 
+// toggleEngine switches between the array and hashlife engines, carrying
+// the current cells across so users can pause, edit under one engine and
+// resume under the other.
+func (g *Game) toggleEngine() {
+	switch g.engine {
+	case EngineArray:
+		g.universe = hashlife.FromDense(denseFromGrid(g.grid), toHashlifeRule(g.grid.Rule))
+		g.engine = EngineHashlife
+	case EngineHashlife:
+		applyDenseToGrid(g.grid, g.universe.ToDense(g.grid.Cols, g.grid.Rows))
+		g.engine = EngineArray
+	}
+}
+
+func denseFromGrid(grid *Grid) [][]bool {
+	dense := make([][]bool, len(grid.Cells))
+	for x, col := range grid.Cells {
+		dense[x] = make([]bool, len(col))
+		for y, cell := range col {
+			dense[x][y] = cell.Alive
+		}
+	}
+	return dense
+}
+
+func applyDenseToGrid(grid *Grid, dense [][]bool) {
+	for x, col := range dense {
+		for y, alive := range col {
+			grid.Cells[x][y].Alive = alive
+		}
+	}
+}
+
+func toHashlifeRule(rule Rule) hashlife.Rule {
+	return hashlife.Rule{Birth: rule.Birth, Survival: rule.Survival}
+}
+
+// Layout honors the actual window size (resizing is enabled in main), so
+// the screen simply matches whatever size the OS gives it.
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	g.screenWidth = outsideWidth
+	g.screenHeight = outsideHeight
 	return outsideWidth, outsideHeight
 }
 
 func NewGame() *Game {
-	grid := NewGrid()
+	grid := NewGrid(DefaultCols, DefaultRows)
 
-	gridOffsetX := (ScreenWidth - Cols*CellSize) / 2
-	gridOffsetY := (ScreenHeight - Rows*CellSize) / 2
+	cellSize := float64(DefaultCellSize)
+	gridOffsetX := (float64(DefaultScreenWidth) - float64(DefaultCols)*cellSize) / 2
+	gridOffsetY := (float64(DefaultScreenHeight) - float64(DefaultRows)*cellSize) / 2
 
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
@@ -263,6 +845,10 @@ func NewGame() *Game {
 
 	return &Game{
 		grid:             grid,
+		screenWidth:      DefaultScreenWidth,
+		screenHeight:     DefaultScreenHeight,
+		cellSize:         cellSize,
+		zoom:             1,
 		gridOffsetX:      gridOffsetX,
 		gridOffsetY:      gridOffsetY,
 		lastTouchedCellX: -1,
@@ -274,7 +860,31 @@ func NewGame() *Game {
 }
 
 func main() {
-	ebiten.SetWindowSize(ScreenWidth, ScreenHeight)
+	headless := flag.Bool("headless", false, "run the simulation without opening a window and export frames")
+	generations := flag.Int("generations", 100, "number of generations to simulate in -headless mode")
+	seed := flag.Int64("seed", 0, "rng seed used to randomize the initial grid in -headless mode")
+	patternPath := flag.String("pattern", "", "RLE pattern file to seed the grid with in -headless mode (overrides -seed)")
+	out := flag.String("out", "out", "output path for -headless mode: a directory for a PGM sequence, or a .gif path for an animation")
+	every := flag.Int("every", 1, "only emit every Kth generation in -headless mode")
+	flag.Parse()
+
+	if *headless {
+		cfg := headlessConfig{
+			generations: *generations,
+			seed:        *seed,
+			pattern:     *patternPath,
+			out:         *out,
+			every:       *every,
+			ratio:       Ratio,
+		}
+		if err := runHeadless(cfg); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	ebiten.SetWindowSize(DefaultScreenWidth, DefaultScreenHeight)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	ebiten.SetWindowTitle("Conway's Game of Life!")
 	game := NewGame()
 	if err := ebiten.RunGame(game); err != nil {
@@ -0,0 +1,39 @@
+package patterns
+
+import "strings"
+
+// Bundled lists the display names of a handful of well-known patterns, in
+// picker order, so the pattern picker overlay has something to list without
+// shipping files. Their RLE source is kept in bundledRLE, keyed by name.
+var Bundled = []string{
+	"Glider",
+	"Gosper Glider Gun",
+	"Pulsar",
+	"LWSS",
+}
+
+var bundledRLE = map[string]string{
+	"Glider": `x = 3, y = 3, rule = B3/S23
+bob$2bo$3o!`,
+	"Gosper Glider Gun": `x = 36, y = 9, rule = B3/S23
+24bo11b$22bobo11b$12b2o6b2o12b2o$11bo3bo4b2o12b2o$2o8bo5bo3b2o14b$2o8bo3bob2o4bobo11b$10bo5bo7bo11b$11bo3bo20b$12b2o!`,
+	"Pulsar": `x = 13, y = 13, rule = B3/S23
+2b3o3b3o2b2$o4bobo4bo$o4bobo4bo$o4bobo4bo$2b3o3b3o2b2$2b3o3b3o2b$o4bobo4bo$o4bobo4bo$o4bobo4bo2$2b3o3b3o2b!`,
+	"LWSS": `x = 5, y = 4, rule = B3/S23
+bo2bo$o4b$o3bo$4o!`,
+}
+
+// LoadBundled loads one of the patterns listed in Bundled by name.
+func LoadBundled(name string) (*Pattern, error) {
+	rle, ok := bundledRLE[name]
+	if !ok {
+		return nil, errUnknownPattern(name)
+	}
+	return LoadRLE(strings.NewReader(rle))
+}
+
+type errUnknownPattern string
+
+func (e errUnknownPattern) Error() string {
+	return "patterns: unknown bundled pattern " + string(e)
+}
@@ -0,0 +1,185 @@
+// Package patterns loads Game of Life patterns from Golly's RLE and
+// Plaintext file formats so they can be stamped onto a running grid.
+package patterns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Pattern is a rectangular block of cells loaded from a pattern file.
+// Cells is indexed [y][x], with true meaning alive.
+type Pattern struct {
+	Width  int
+	Height int
+	Cells  [][]bool
+}
+
+// maxPatternDimension bounds a parsed width, height, or run-length count.
+// Without it, a header like "x = 200000000, y = 200000000" would make
+// newPattern try to allocate trillions of bools, and an oversized run
+// count would spin a loop far longer than the declared row could ever
+// use. Far larger than any real-world pattern file needs.
+const maxPatternDimension = 10_000
+
+// LoadRLE parses a Golly RLE pattern: a header line of the form
+// "x = W, y = H, rule = B3/S23" followed by run-length encoded rows made
+// of 'b' (dead), 'o' (alive), '$' (end of row) tokens terminated by '!'.
+// Lines starting with '#' are comments and are skipped.
+func LoadRLE(r io.Reader) (*Pattern, error) {
+	scanner := bufio.NewScanner(r)
+
+	var width, height int
+	var header string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		header = line
+		break
+	}
+	if header == "" {
+		return nil, fmt.Errorf("rle: missing header line")
+	}
+
+	var err error
+	width, height, err = parseRLEHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var body strings.Builder
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		body.WriteString(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("rle: %w", err)
+	}
+
+	pattern := newPattern(width, height)
+
+	x, y := 0, 0
+	count := 0
+	for _, r := range body.String() {
+		switch {
+		case r >= '0' && r <= '9':
+			count = count*10 + int(r-'0')
+			if count > maxPatternDimension {
+				count = maxPatternDimension
+			}
+		case r == 'b' || r == 'o':
+			n := count
+			if n == 0 {
+				n = 1
+			}
+			// Only the cells up to width are ever written; clamping the
+			// loop bound to that (rather than looping n times) keeps an
+			// oversized run count, e.g. a single "999999999999999999999o"
+			// token, from spinning long after every remaining iteration
+			// would have been a no-op.
+			if y < height {
+				end := x + n
+				if end > width {
+					end = width
+				}
+				for i := x; i < end; i++ {
+					pattern.Cells[y][i] = r == 'o'
+				}
+			}
+			x += n
+			count = 0
+		case r == '$':
+			n := count
+			if n == 0 {
+				n = 1
+			}
+			y += n
+			x = 0
+			count = 0
+		case r == '!':
+			return pattern, nil
+		default:
+			// unrecognized token (e.g. stray whitespace); ignore
+		}
+	}
+
+	return pattern, nil
+}
+
+func parseRLEHeader(header string) (width, height int, err error) {
+	for _, field := range strings.Split(header, ",") {
+		field = strings.TrimSpace(field)
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+
+		switch key {
+		case "x":
+			width, err = strconv.Atoi(value)
+		case "y":
+			height, err = strconv.Atoi(value)
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("rle: invalid header %q: %w", header, err)
+		}
+	}
+
+	if width == 0 || height == 0 {
+		return 0, 0, fmt.Errorf("rle: invalid header %q: missing x/y", header)
+	}
+	if width < 0 || height < 0 || width > maxPatternDimension || height > maxPatternDimension {
+		return 0, 0, fmt.Errorf("rle: invalid header %q: x/y must be between 1 and %d", header, maxPatternDimension)
+	}
+
+	return width, height, nil
+}
+
+// LoadPlaintext parses a Plaintext (.cells) pattern: comment lines begin
+// with '!', '.' marks a dead cell and 'O' marks an alive cell.
+func LoadPlaintext(r io.Reader) (*Pattern, error) {
+	scanner := bufio.NewScanner(r)
+
+	var rows []string
+	width := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "!") {
+			continue
+		}
+		rows = append(rows, line)
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("plaintext: %w", err)
+	}
+
+	pattern := newPattern(width, len(rows))
+	for y, row := range rows {
+		for x, r := range row {
+			pattern.Cells[y][x] = r == 'O'
+		}
+	}
+
+	return pattern, nil
+}
+
+func newPattern(width, height int) *Pattern {
+	cells := make([][]bool, height)
+	for y := range cells {
+		cells[y] = make([]bool, width)
+	}
+	return &Pattern{Width: width, Height: height, Cells: cells}
+}
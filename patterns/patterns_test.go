@@ -0,0 +1,146 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+)
+
+func cellsString(p *Pattern) string {
+	var b strings.Builder
+	for _, row := range p.Cells {
+		for _, alive := range row {
+			if alive {
+				b.WriteByte('o')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func TestLoadRLEGlider(t *testing.T) {
+	const rle = `#N Glider
+x = 3, y = 3, rule = B3/S23
+bob$2bo$3o!`
+
+	p, err := LoadRLE(strings.NewReader(rle))
+	if err != nil {
+		t.Fatalf("LoadRLE: unexpected error: %v", err)
+	}
+	if p.Width != 3 || p.Height != 3 {
+		t.Fatalf("LoadRLE: size = %dx%d, want 3x3", p.Width, p.Height)
+	}
+
+	want := ".o.\n..o\nooo\n"
+	if got := cellsString(p); got != want {
+		t.Fatalf("LoadRLE: cells =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLoadRLEMultiDigitRuns(t *testing.T) {
+	// A 12x12 frame: a run of 12 "o", then "11$" (ten empty rows), then
+	// another run of 12 "o", exercising multi-digit counts on both the
+	// live-cell and end-of-row tokens.
+	const rle = `x = 12, y = 12, rule = B3/S23
+12o11$12o!`
+
+	p, err := LoadRLE(strings.NewReader(rle))
+	if err != nil {
+		t.Fatalf("LoadRLE: unexpected error: %v", err)
+	}
+
+	full := strings.Repeat("o", 12) + "\n"
+	want := full + strings.Repeat(strings.Repeat(".", 12)+"\n", 10) + full
+	if got := cellsString(p); got != want {
+		t.Fatalf("LoadRLE: cells =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLoadRLEMissingHeader(t *testing.T) {
+	if _, err := LoadRLE(strings.NewReader("# just a comment\n")); err == nil {
+		t.Fatal("LoadRLE: expected error for missing header")
+	}
+}
+
+// TestLoadRLERejectsOversizedHeader guards against the header's x/y fields
+// driving an allocation sized off an attacker- or typo-controlled number
+// before a single cell is ever read.
+func TestLoadRLERejectsOversizedHeader(t *testing.T) {
+	if _, err := LoadRLE(strings.NewReader("x = 200000000, y = 200000000\no!")); err == nil {
+		t.Fatal("LoadRLE: expected error for a header past the dimension cap")
+	}
+}
+
+// TestLoadRLEClampsOversizedRunCount guards against a single run-length
+// token with an enormous count looping far past the row width it could
+// ever affect.
+func TestLoadRLEClampsOversizedRunCount(t *testing.T) {
+	p, err := LoadRLE(strings.NewReader("x = 5, y = 5\n999999999999999999999o!"))
+	if err != nil {
+		t.Fatalf("LoadRLE: unexpected error: %v", err)
+	}
+
+	want := "ooooo\n.....\n.....\n.....\n.....\n"
+	if got := cellsString(p); got != want {
+		t.Fatalf("LoadRLE: cells =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParseRLEHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		wantWidth  int
+		wantHeight int
+		wantErr    bool
+	}{
+		{name: "basic", header: "x = 3, y = 3, rule = B3/S23", wantWidth: 3, wantHeight: 3},
+		{name: "no rule field", header: "x = 10, y = 4", wantWidth: 10, wantHeight: 4},
+		{name: "missing x", header: "y = 3", wantErr: true},
+		{name: "non-numeric", header: "x = a, y = 3", wantErr: true},
+		{name: "width over the dimension cap", header: "x = 200000000, y = 3", wantErr: true},
+		{name: "height over the dimension cap", header: "x = 3, y = 200000000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height, err := parseRLEHeader(tt.header)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseRLEHeader(%q): expected error", tt.header)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRLEHeader(%q): unexpected error: %v", tt.header, err)
+			}
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Fatalf("parseRLEHeader(%q) = %d,%d, want %d,%d", tt.header, width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestLoadPlaintext(t *testing.T) {
+	const plaintext = `!Name: Glider
+!
+.O.
+..O
+OOO
+`
+
+	p, err := LoadPlaintext(strings.NewReader(plaintext))
+	if err != nil {
+		t.Fatalf("LoadPlaintext: unexpected error: %v", err)
+	}
+	if p.Width != 3 || p.Height != 3 {
+		t.Fatalf("LoadPlaintext: size = %dx%d, want 3x3", p.Width, p.Height)
+	}
+
+	want := ".o.\n..o\nooo\n"
+	if got := cellsString(p); got != want {
+		t.Fatalf("LoadPlaintext: cells =\n%s\nwant\n%s", got, want)
+	}
+}
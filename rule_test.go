@@ -0,0 +1,87 @@
+package main
+
+import "testing"
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Rule
+		wantErr bool
+	}{
+		{name: "conway", in: "B3/S23", want: Rule{Birth: 1 << 3, Survival: 1<<2 | 1<<3}},
+		{name: "highlife", in: "B36/S23", want: Rule{Birth: 1<<3 | 1<<6, Survival: 1<<2 | 1<<3}},
+		{name: "seeds, empty survival", in: "B2/S", want: Rule{Birth: 1 << 2, Survival: 0}},
+		{name: "missing slash", in: "B3S23", wantErr: true},
+		{name: "missing B prefix", in: "3/S23", wantErr: true},
+		{name: "missing S prefix", in: "B3/23", wantErr: true},
+		{name: "non-digit neighbor count", in: "B3/Sx", wantErr: true},
+		{name: "neighbor count out of range", in: "B9/S23", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRule(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRule(%q): expected error, got %+v", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRule(%q): unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseRule(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDigitMask(t *testing.T) {
+	tests := []struct {
+		digits  string
+		want    uint16
+		wantErr bool
+	}{
+		{digits: "", want: 0},
+		{digits: "23", want: 1<<2 | 1<<3},
+		{digits: "368", want: 1<<3 | 1<<6 | 1<<8},
+		{digits: "x", wantErr: true},
+		{digits: "9", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDigitMask(tt.digits)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("parseDigitMask(%q): expected error, got %d", tt.digits, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parseDigitMask(%q): unexpected error: %v", tt.digits, err)
+		}
+		if got != tt.want {
+			t.Fatalf("parseDigitMask(%q) = %d, want %d", tt.digits, got, tt.want)
+		}
+	}
+}
+
+// TestCountNeighboorsWrap checks that Grid.CountNeighboors only treats the
+// grid as toroidal when Wrap is set: a live cell in one corner should count
+// as a neighbor of the diagonally opposite corner with wrap enabled, and
+// not at all with it disabled.
+func TestCountNeighboorsWrap(t *testing.T) {
+	grid := NewGrid(3, 3)
+	grid.Cells[0][0].Alive = true
+
+	if got := grid.CountNeighboors(2, 2); got != 0 {
+		t.Fatalf("CountNeighboors(2, 2) without wrap = %d, want 0", got)
+	}
+
+	grid.Wrap = true
+	if got := grid.CountNeighboors(2, 2); got != 1 {
+		t.Fatalf("CountNeighboors(2, 2) with wrap = %d, want 1", got)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/lucaslopesx/game-of-life-go/patterns"
+)
+
+func aliveCells(grid *Grid) [][2]int {
+	var cells [][2]int
+	for x, col := range grid.Cells {
+		for y, cell := range col {
+			if cell.Alive {
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i][0] != cells[j][0] {
+			return cells[i][0] < cells[j][0]
+		}
+		return cells[i][1] < cells[j][1]
+	})
+	return cells
+}
+
+func sameCellSet(got, want [][2]int) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	sort.Slice(want, func(i, j int) bool {
+		if want[i][0] != want[j][0] {
+			return want[i][0] < want[j][0]
+		}
+		return want[i][1] < want[j][1]
+	})
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestGridStampRotation checks that Grid.Stamp's rotation quarter-turns
+// place an asymmetric L-shaped pattern (alive at (0,0), (1,0), (0,1), in
+// pattern-local x,y) the way a 2D clockwise rotation would.
+func TestGridStampRotation(t *testing.T) {
+	pattern := &patterns.Pattern{
+		Width:  2,
+		Height: 2,
+		Cells: [][]bool{
+			{true, true},  // y=0: (0,0) and (1,0) alive
+			{true, false}, // y=1: (0,1) alive
+		},
+	}
+
+	tests := []struct {
+		rotation int
+		want     [][2]int
+	}{
+		{rotation: 0, want: [][2]int{{0, 0}, {1, 0}, {0, 1}}},
+		{rotation: 1, want: [][2]int{{1, 0}, {1, 1}, {0, 0}}},
+		{rotation: 2, want: [][2]int{{1, 1}, {0, 1}, {1, 0}}},
+		{rotation: 3, want: [][2]int{{0, 1}, {0, 0}, {1, 1}}},
+	}
+
+	for _, tt := range tests {
+		grid := NewGrid(4, 4)
+		grid.Stamp(pattern, 0, 0, tt.rotation)
+
+		got := aliveCells(grid)
+		if !sameCellSet(got, tt.want) {
+			t.Errorf("Stamp rotation %d: alive cells = %v, want %v", tt.rotation, got, tt.want)
+		}
+	}
+}